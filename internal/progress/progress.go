@@ -0,0 +1,230 @@
+// Package progress renders a live, mpb-style multi-bar progress display for
+// long-running plot scans: one bar per tracked directory plus an aggregate,
+// each reporting files-visited / files-matched / bytes-read and the current
+// file name.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventKind identifies what a Report call represents.
+type EventKind int
+
+const (
+	EventVisited EventKind = iota
+	EventMatched
+	EventBytesRead
+)
+
+// Event is emitted by a scanner worker as it processes files under a
+// tracked directory.
+type Event struct {
+	Dir   string
+	Kind  EventKind
+	Bytes int64
+	File  string
+}
+
+type barState struct {
+	visited   uint64
+	matched   uint64
+	bytesRead int64
+	file      string
+}
+
+// Reporter collects scan Events from one or more workers and renders a
+// fixed set of decorators (name, counters, percentage, ETA, speed) per
+// tracked directory plus an aggregate line, modeled on the mpb decorator
+// pattern. It is safe for concurrent use by multiple workers.
+type Reporter struct {
+	out      io.Writer
+	start    time.Time
+	interval time.Duration
+
+	mu        sync.Mutex
+	order     []string
+	bars      map[string]*barState
+	total     map[string]int64
+	lastLines int
+
+	events chan Event
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewReporter creates a Reporter that renders to out every interval until
+// Stop is called. dirs seeds the bar order so directories appear in
+// --dirs order even before their first event arrives.
+func NewReporter(out io.Writer, dirs []string, interval time.Duration) *Reporter {
+	r := &Reporter{
+		out:      out,
+		start:    time.Now(),
+		interval: interval,
+		bars:     make(map[string]*barState, len(dirs)),
+		total:    make(map[string]int64, len(dirs)),
+		events:   make(chan Event, 256),
+		done:     make(chan struct{}),
+	}
+	for _, dir := range dirs {
+		r.order = append(r.order, dir)
+		r.bars[dir] = &barState{}
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r
+}
+
+// SetTotal records an estimated file count for dir, used to render a
+// percentage and ETA decorator once known.
+func (r *Reporter) SetTotal(dir string, total int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total[dir] = total
+}
+
+// Report enqueues ev for rendering. It never blocks scanning: if the
+// internal buffer is full the event is dropped, since progress output is
+// best-effort.
+func (r *Reporter) Report(ev Event) {
+	select {
+	case r.events <- ev:
+	default:
+	}
+}
+
+// Stop flushes a final render and stops the background renderer.
+func (r *Reporter) Stop() {
+	close(r.done)
+	r.wg.Wait()
+}
+
+func (r *Reporter) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev := <-r.events:
+			r.apply(ev)
+		case <-ticker.C:
+			r.render()
+		case <-r.done:
+			r.drain()
+			r.render()
+			return
+		}
+	}
+}
+
+func (r *Reporter) drain() {
+	for {
+		select {
+		case ev := <-r.events:
+			r.apply(ev)
+		default:
+			return
+		}
+	}
+}
+
+func (r *Reporter) apply(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.bars[ev.Dir]
+	if !ok {
+		b = &barState{}
+		r.bars[ev.Dir] = b
+		r.order = append(r.order, ev.Dir)
+	}
+	switch ev.Kind {
+	case EventVisited:
+		b.visited++
+		b.file = ev.File
+	case EventMatched:
+		b.matched++
+	case EventBytesRead:
+		b.bytesRead += ev.Bytes
+	}
+}
+
+func (r *Reporter) render() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elapsed := time.Since(r.start)
+	var totalVisited, totalMatched uint64
+	var totalBytes int64
+
+	var sb strings.Builder
+	if r.lastLines > 0 {
+		fmt.Fprintf(&sb, "\033[%dA", r.lastLines)
+	}
+	for _, dir := range r.order {
+		b := r.bars[dir]
+		totalVisited += b.visited
+		totalMatched += b.matched
+		totalBytes += b.bytesRead
+		sb.WriteString(renderBar(dir, b, r.total[dir], elapsed))
+		sb.WriteString("\033[K\n")
+	}
+	sb.WriteString(renderAggregate(totalVisited, totalMatched, totalBytes, elapsed))
+	sb.WriteString("\033[K\n")
+	r.lastLines = len(r.order) + 1
+	fmt.Fprint(r.out, sb.String())
+}
+
+func renderBar(name string, b *barState, total int64, elapsed time.Duration) string {
+	pct, eta := "", ""
+	if total > 0 {
+		ratio := float64(b.visited) / float64(total)
+		if ratio > 1 {
+			ratio = 1
+		}
+		pct = fmt.Sprintf(" %5.1f%%", ratio*100)
+		if ratio > 0 {
+			remaining := time.Duration(float64(elapsed)/ratio) - elapsed
+			if remaining < 0 {
+				remaining = 0
+			}
+			eta = fmt.Sprintf(" ETA %s", remaining.Round(time.Second))
+		}
+	}
+	return fmt.Sprintf("%-28s visited=%-7d matched=%-7d%s%s %9s %s",
+		truncate(name, 28), b.visited, b.matched, pct, eta, speedString(b.bytesRead, elapsed), truncate(b.file, 36))
+}
+
+func renderAggregate(visited, matched uint64, bytes int64, elapsed time.Duration) string {
+	return fmt.Sprintf("%-28s visited=%-7d matched=%-7d %9s", "TOTAL", visited, matched, speedString(bytes, elapsed))
+}
+
+func speedString(bytes int64, elapsed time.Duration) string {
+	if elapsed <= 0 || bytes <= 0 {
+		return "0 B/s"
+	}
+	bps := float64(bytes) / elapsed.Seconds()
+	const unit = 1024.0
+	if bps < unit {
+		return fmt.Sprintf("%.0f B/s", bps)
+	}
+	div, exp := unit, 0
+	for n := bps / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB/s", bps/div, "KMGTPE"[exp])
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	if n <= 1 {
+		return s[:n]
+	}
+	return s[:n-1] + "…"
+}
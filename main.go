@@ -1,114 +1,373 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/massnetorg/mass-core/logging"
 	"github.com/massnetorg/mass-core/massutil"
 	"github.com/massnetorg/mass-core/poc"
 	"github.com/massnetorg/mass-core/poc/chiawallet"
 	"github.com/urfave/cli/v2"
+
+	"github.com/fatzero/mass-binding-target/internal/progress"
 )
 
 var (
-	getBindingListArgFilename     string
-	getBindingListFlagOverwrite   bool
-	getBindingListFlagListAll     bool
-	getBindingListFlagKeystore    string
-	getBindingListFlagPlotType    string
-	getBindingListFlagDirectories []string
+	getBindingListArgFilename          string
+	getBindingListFlagOverwrite        bool
+	getBindingListFlagListAll          bool
+	getBindingListFlagKeystores        []string
+	getBindingListFlagPlotType         string
+	getBindingListFlagRequireOwnership bool
+	getBindingListFlagDirectories      []string
+	getBindingListFlagWorkers          int
+	getBindingListFlagFollowSymlinks   bool
+	getBindingListFlagMaxDepth         int
+	getBindingListFlagProgress         bool
+	getBindingListFlagMerge            bool
+	getBindingListFlagPrune            bool
+	getBindingListFlagDiff             string
 )
 
+// bindingListFile is the on-disk JSON shape written and read by this tool.
+// It embeds the library's massutil.BindingList so existing consumers keep
+// reading the same Plots/TotalCount/DefaultCount/ChiaCount fields, and adds
+// Paths as an additive, optional side table (plot identity -> source file
+// path) so a later --merge/--prune invocation can tell whether a
+// previously recorded plot still exists on disk.
+type bindingListFile struct {
+	massutil.BindingList
+	Paths map[string]string `json:"paths,omitempty"`
+}
+
+// plotIdentity returns a stable string identifying a plot's Target/Type/Size,
+// used as the Paths map key since it is independent of slice position and
+// survives massutil.BindingList.RemoveDuplicate reordering the plots.
+func plotIdentity(p massutil.BindingPlot) string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func loadBindingListFile(filename string) (*bindingListFile, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var f bindingListFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// recomputeCounts derives TotalCount/DefaultCount/ChiaCount from list.Plots,
+// needed after merging or pruning changes the slice out from under the
+// counts the scanner originally computed.
+func recomputeCounts(list *massutil.BindingList) {
+	var defaultCount, chiaCount uint64
+	for _, p := range list.Plots {
+		switch p.Type {
+		case uint8(poc.ProofTypeDefault):
+			defaultCount++
+		case uint8(poc.ProofTypeChia):
+			chiaCount++
+		}
+	}
+	list.DefaultCount = defaultCount
+	list.ChiaCount = chiaCount
+	list.TotalCount = defaultCount + chiaCount
+}
+
+// pruneMissing drops plots whose recorded source path no longer exists on
+// disk. Plots with no recorded path (e.g. loaded from a binding list written
+// before --merge existed) cannot be verified and are kept as-is.
+func pruneMissing(list *massutil.BindingList, paths map[string]string) (kept int, pruned int, unverifiable int) {
+	survivors := list.Plots[:0]
+	for _, p := range list.Plots {
+		path, ok := paths[plotIdentity(p)]
+		if !ok {
+			unverifiable++
+			survivors = append(survivors, p)
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			pruned++
+			continue
+		}
+		survivors = append(survivors, p)
+	}
+	list.Plots = survivors
+	return len(survivors), pruned, unverifiable
+}
+
+// diffBindingLists reports plots present in next but absent from prev
+// (added) and plots present in prev but absent from next (removed).
+func diffBindingLists(prev, next *massutil.BindingList) (added, removed []massutil.BindingPlot) {
+	prevSet := make(map[string]massutil.BindingPlot, len(prev.Plots))
+	for _, p := range prev.Plots {
+		prevSet[plotIdentity(p)] = p
+	}
+	nextSet := make(map[string]massutil.BindingPlot, len(next.Plots))
+	for _, p := range next.Plots {
+		nextSet[plotIdentity(p)] = p
+		if _, ok := prevSet[plotIdentity(p)]; !ok {
+			added = append(added, p)
+		}
+	}
+	for _, p := range prev.Plots {
+		if _, ok := nextSet[plotIdentity(p)]; !ok {
+			removed = append(removed, p)
+		}
+	}
+	return added, removed
+}
+
+// isTerminal reports whether f is attached to a TTY, used to auto-disable
+// --progress when stdout is redirected to a file or pipe.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// scanFlags are shared between the default scan action and the watch
+// subcommand, since both need to know where and how to look for plots.
+var scanFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:    "all",
+		Aliases: []string{"a"},
+		Usage:   "list all files instead of only plotted files",
+		Value:   false,
+	},
+	&cli.StringSliceFlag{
+		Name:  "keystore",
+		Usage: "specify one or more keystores to eliminate files without private key; a plot is kept if any of them owns it",
+		Value: nil,
+	},
+	&cli.StringFlag{
+		Name:    "type",
+		Aliases: []string{"t"},
+		Usage:   "specify the searching plot type: m1 (for native MassDB), m2 (for Chia Plot), a comma list of both, or \"all\"",
+		Value:   "",
+	},
+	&cli.BoolFlag{
+		Name:  "require-ownership",
+		Usage: "hard-error on the first plot not owned by any --keystore, useful in CI checks",
+		Value: false,
+	},
+	&cli.StringSliceFlag{
+		Name:    "dirs",
+		Aliases: []string{"d"},
+		Usage:   "specify the searching directories",
+		Value:   nil,
+	},
+	&cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of concurrent workers used to inspect plot files, defaults to the number of CPUs",
+		Value: runtime.NumCPU(),
+	},
+	&cli.BoolFlag{
+		Name:  "follow-symlinks",
+		Usage: "follow symbolic links while walking the searching directories",
+		Value: false,
+	},
+	&cli.IntFlag{
+		Name:  "max-depth",
+		Usage: "limit recursion depth below each --dirs entry, -1 for unlimited",
+		Value: -1,
+	},
+}
+
+// applyScanFlags reads the scanFlags values out of context into the package
+// globals consumed by getOfflineBindingList.
+func applyScanFlags(context *cli.Context) {
+	getBindingListFlagListAll = context.Bool("all")
+	getBindingListFlagKeystores = context.StringSlice("keystore")
+	getBindingListFlagPlotType = context.String("type")
+	getBindingListFlagRequireOwnership = context.Bool("require-ownership")
+	getBindingListFlagDirectories = context.StringSlice("dirs")
+	getBindingListFlagWorkers = context.Int("workers")
+	getBindingListFlagFollowSymlinks = context.Bool("follow-symlinks")
+	getBindingListFlagMaxDepth = context.Int("max-depth")
+}
+
+// validateScanFlags checks the values applyScanFlags just read in, so a bad
+// flag value (e.g. a negative --workers) surfaces as a normal CLI error
+// instead of a runtime panic once it reaches sizing a buffered channel.
+func validateScanFlags() error {
+	if getBindingListFlagWorkers < 1 {
+		return fmt.Errorf("invalid --workers value %d, must be >= 1", getBindingListFlagWorkers)
+	}
+	return nil
+}
+
 func main() {
 	app := &cli.App{
 		Name:      "massBindingTarget",
 		Usage:     "Get MASS Binding Target List by searching for plot files from disk.",
 		UsageText: "./massBindingTarget <export_filename> [flags]",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "overwrite",
 				Aliases: []string{"o"},
 				Usage:   "overwrite existed binding list file",
 				Value:   false,
 			},
+		}, append(scanFlags,
+			&cli.BoolFlag{
+				Name:  "progress",
+				Usage: "render a live multi-bar progress display while scanning, auto-disabled when stdout is not a terminal",
+				Value: false,
+			},
 			&cli.BoolFlag{
-				Name:    "all",
-				Aliases: []string{"a"},
-				Usage:   "list all files instead of only plotted files",
+				Name:    "merge",
+				Aliases: []string{"append"},
+				Usage:   "load the existing binding list file and union it with this scan's results instead of requiring --overwrite",
 				Value:   false,
 			},
-			&cli.StringFlag{
-				Name:  "keystore",
-				Usage: "specify the keystore to eliminate files without private key",
-				Value: "",
+			&cli.BoolFlag{
+				Name:  "prune",
+				Usage: "drop entries whose recorded plot file no longer exists on disk",
+				Value: false,
 			},
 			&cli.StringFlag{
-				Name:    "type",
-				Aliases: []string{"t"},
-				Usage:   "specify the searching plot type: m1 (for native MassDB) or m2 (for Chia Plot)",
-				Value:   "",
-			},
-			&cli.StringSliceFlag{
-				Name:    "dirs",
-				Aliases: []string{"d"},
-				Usage:   "specify the searching directories",
-				Value:   nil,
+				Name:  "diff",
+				Usage: "print plots added/removed versus the binding list file at <old.json>",
+				Value: "",
 			},
+		)...),
+		Commands: []*cli.Command{
+			watchCommand,
 		},
-		Action: func(context *cli.Context) error {
+		Action: func(cliCtx *cli.Context) error {
 			// prepare arguments and flags
-			if context.NArg() < 1 {
-				return cli.ShowAppHelp(context)
+			if cliCtx.NArg() < 1 {
+				return cli.ShowAppHelp(cliCtx)
 			}
-			abs, err := filepath.Abs(context.Args().First())
+			abs, err := filepath.Abs(cliCtx.Args().First())
 			if err != nil {
-				logging.CPrint(logging.ERROR, "wrong filename format", logging.LogFormat{"err": err, "filename": context.Args().First()})
+				logging.CPrint(logging.ERROR, "wrong filename format", logging.LogFormat{"err": err, "filename": cliCtx.Args().First()})
 				return err
 			}
 			fi, err := os.Stat(abs)
 			if err == nil && fi.IsDir() {
-				logging.CPrint(logging.ERROR, "filename is a directory", logging.LogFormat{"filename": context.Args().First()})
+				logging.CPrint(logging.ERROR, "filename is a directory", logging.LogFormat{"filename": cliCtx.Args().First()})
 				return err
 			}
 			getBindingListArgFilename = abs
-			getBindingListFlagOverwrite = context.Bool("overwrite")
-			getBindingListFlagListAll = context.Bool("all")
-			getBindingListFlagKeystore = context.String("keystore")
-			getBindingListFlagPlotType = context.String("type")
-			getBindingListFlagDirectories = context.StringSlice("dirs")
+			getBindingListFlagOverwrite = cliCtx.Bool("overwrite")
+			applyScanFlags(cliCtx)
+			if err := validateScanFlags(); err != nil {
+				logging.CPrint(logging.ERROR, "invalid flag value", logging.LogFormat{"err": err})
+				return err
+			}
+			getBindingListFlagProgress = cliCtx.Bool("progress") && isTerminal(os.Stdout)
+			getBindingListFlagMerge = cliCtx.Bool("merge")
+			getBindingListFlagPrune = cliCtx.Bool("prune")
+			getBindingListFlagDiff = cliCtx.String("diff")
 
 			// main logics
 			_, err = os.Stat(getBindingListArgFilename)
-			if !os.IsNotExist(err) && !getBindingListFlagOverwrite {
-				logging.CPrint(logging.ERROR, "cannot overwrite existed file, try again with --overwrite", logging.LogFormat{
+			if !os.IsNotExist(err) && !getBindingListFlagOverwrite && !getBindingListFlagMerge {
+				logging.CPrint(logging.ERROR, "cannot overwrite existed file, try again with --overwrite or --merge", logging.LogFormat{
 					"filename": getBindingListArgFilename,
 				})
 				return err
 			}
 
-			list, err := getOfflineBindingList()
+			var existing *bindingListFile
+			if getBindingListFlagMerge {
+				existing, err = loadBindingListFile(getBindingListArgFilename)
+				if err != nil && !os.IsNotExist(err) {
+					logging.CPrint(logging.ERROR, "fail to load existing binding list for merge", logging.LogFormat{"err": err, "filename": getBindingListArgFilename})
+					return err
+				}
+			}
+
+			interruptCh := make(chan os.Signal, 2)
+			signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				select {
+				case <-interruptCh:
+					logging.CPrint(logging.WARN, "cancel searching plot files")
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			list, paths, err := getOfflineBindingList(ctx)
 			if err != nil {
 				logging.CPrint(logging.ERROR, "fail to get binding list", logging.LogFormat{"err": err})
 				return err
 			}
 			list = list.RemoveDuplicate()
 
+			if existing != nil {
+				list.Plots = append(existing.Plots, list.Plots...)
+				for identity, path := range paths {
+					if existing.Paths == nil {
+						existing.Paths = make(map[string]string)
+					}
+					existing.Paths[identity] = path
+				}
+				paths = existing.Paths
+				list = list.RemoveDuplicate()
+				recomputeCounts(list)
+				logging.CPrint(logging.INFO, "merged scan results into existing binding list", logging.LogFormat{"total_count": list.TotalCount})
+			}
+
+			if getBindingListFlagPrune {
+				kept, pruned, unverifiable := pruneMissing(list, paths)
+				recomputeCounts(list)
+				logging.CPrint(logging.INFO, "pruned missing plot files", logging.LogFormat{
+					"kept": kept, "pruned": pruned, "unverifiable": unverifiable,
+				})
+			}
+
+			if getBindingListFlagDiff != "" {
+				oldFile, diffErr := loadBindingListFile(getBindingListFlagDiff)
+				if diffErr != nil {
+					logging.CPrint(logging.ERROR, "fail to load binding list for --diff", logging.LogFormat{"err": diffErr, "filename": getBindingListFlagDiff})
+					return diffErr
+				}
+				added, removed := diffBindingLists(&oldFile.BindingList, list)
+				fmt.Printf("diff vs %s: %d added, %d removed\n", getBindingListFlagDiff, len(added), len(removed))
+				for _, p := range added {
+					fmt.Printf("+ %s\n", plotIdentity(p))
+				}
+				for _, p := range removed {
+					fmt.Printf("- %s\n", plotIdentity(p))
+				}
+			}
+
 			if len(list.Plots) == 0 {
 				fmt.Println("saved nothing in the binding list")
 				return nil
 			}
 
-			data, err := json.MarshalIndent(list, "", "  ")
+			data, err := json.MarshalIndent(&bindingListFile{BindingList: *list, Paths: paths}, "", "  ")
 			if err != nil {
 				logging.CPrint(logging.ERROR, "fail to marshal json", logging.LogFormat{
 					"err":         err,
@@ -137,204 +396,758 @@ func main() {
 	}
 }
 
-func getOfflineBindingList() (list *massutil.BindingList, err error) {
+// parsePlotTypes expands the --type flag into an ordered, deduplicated list
+// of "m1"/"m2" scans to run: a single value, a comma list (e.g. "m1,m2"), or
+// "all" as shorthand for both.
+func parsePlotTypes(flag string) ([]string, error) {
+	if flag == "" {
+		return nil, errors.New(`invalid --type flag, should be m1 (for native MassDB), m2 (for Chia Plot), a comma list of both, or "all"`)
+	}
+
+	var types []string
+	seen := make(map[string]bool)
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	for _, part := range strings.Split(flag, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "all":
+			add("m1")
+			add("m2")
+		case "m1":
+			add("m1")
+		case "m2":
+			add("m2")
+		default:
+			return nil, fmt.Errorf("invalid --type value %q, should be m1, m2, or all", part)
+		}
+	}
+	return types, nil
+}
+
+// getOfflineBindingList scans --dirs for plot files and builds the resulting
+// binding list. ctx is the caller's interrupt-aware context: getOfflineBindingList
+// no longer sets up its own signal handling, so long-running callers like
+// watch can reuse a single long-lived ctx across repeated re-scans instead of
+// leaking a fresh signal.Notify registration on every call.
+func getOfflineBindingList(ctx context.Context) (list *massutil.BindingList, paths map[string]string, err error) {
 	var absDirectories []string
 	for _, dir := range getBindingListFlagDirectories {
 		absDir, err := filepath.Abs(dir)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		absDirectories = append(absDirectories, absDir)
 	}
 
-	interruptCh := make(chan os.Signal, 2)
-	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
-
 	logging.CPrint(logging.INFO, "searching for plot files from disk, this may take a while (enter CTRL+C to cancel running)",
-		logging.LogFormat{"dir_count": len(absDirectories)})
+		logging.LogFormat{"dir_count": len(absDirectories), "workers": getBindingListFlagWorkers})
 
-	var plots []massutil.BindingPlot
-	var defaultCount, chiaCount uint64
-	switch getBindingListFlagPlotType {
-	case "m1":
-		plots, err = getOfflineBindingListV1(interruptCh, absDirectories, getBindingListFlagListAll)
-		defaultCount = uint64(len(plots))
-	case "m2":
-		plots, err = getOfflineBindingListV2(interruptCh, absDirectories, getBindingListFlagListAll, getBindingListFlagKeystore)
-		chiaCount = uint64(len(plots))
-	default:
-		err = errors.New("invalid --type flag, should be m1 (for native MassDB) or m2 (for Chia Plot)")
-		return
+	var reporter *progress.Reporter
+	if getBindingListFlagProgress {
+		reporter = progress.NewReporter(os.Stdout, absDirectories, 200*time.Millisecond)
+		defer reporter.Stop()
+		for dir, count := range preCountFiles(absDirectories, getBindingListFlagFollowSymlinks, getBindingListFlagMaxDepth) {
+			reporter.SetTotal(dir, count)
+		}
 	}
+
+	types, err := parsePlotTypes(getBindingListFlagPlotType)
 	if err != nil {
-		logging.CPrint(logging.ERROR, "fail to get offline binding list", logging.LogFormat{"err": err})
 		return
 	}
 
+	var plots []massutil.BindingPlot
+	paths = make(map[string]string)
+	var defaultCount, chiaCount uint64
+	for _, t := range types {
+		var typePlots []massutil.BindingPlot
+		var typePaths map[string]string
+		switch t {
+		case "m1":
+			typePlots, typePaths, err = getOfflineBindingListV1(ctx, absDirectories, getBindingListFlagListAll, reporter)
+			defaultCount += uint64(len(typePlots))
+		case "m2":
+			typePlots, typePaths, err = getOfflineBindingListV2(ctx, absDirectories, getBindingListFlagListAll, getBindingListFlagKeystores, reporter)
+			chiaCount += uint64(len(typePlots))
+		}
+		if err != nil {
+			logging.CPrint(logging.ERROR, "fail to get offline binding list", logging.LogFormat{"err": err, "type": t})
+			return
+		}
+		plots = append(plots, typePlots...)
+		for identity, path := range typePaths {
+			paths[identity] = path
+		}
+	}
+
 	list = &massutil.BindingList{
 		Plots:        plots,
 		TotalCount:   defaultCount + chiaCount,
 		DefaultCount: defaultCount,
 		ChiaCount:    chiaCount,
 	}
-	return list, nil
+	return list, paths, nil
 }
 
-func getOfflineBindingListV1(interruptCh chan os.Signal, dirs []string, all bool) ([]massutil.BindingPlot, error) {
-	regStrB, suffixB := `^\d+_[A-F0-9]{66}_\d{2}\.MASSDB$`, ".MASSDB"
-	regExpB, err := regexp.Compile(regStrB)
+// preCountFiles does a cheap, metadata-only walk of dirs (no file opens) to
+// estimate how many files each directory holds, so the progress bars can
+// render a percentage and ETA before the much more expensive plot-reading
+// scan finishes. Errors are logged and otherwise ignored, since a missed
+// count only degrades the progress display, not the scan itself.
+func preCountFiles(dirs []string, followSymlinks bool, maxDepth int) map[string]int64 {
+	counts := make(map[string]int64, len(dirs))
+	for _, dir := range dirs {
+		var count int64
+		countFilesTree(dir, 0, maxDepth, followSymlinks, make(map[string]bool), &count)
+		counts[dir] = count
+	}
+	return counts
+}
+
+// countFilesTree counts the files under path into count, recursing into
+// directory symlinks (subject to visited, which tracks resolved real paths
+// across the whole call tree to avoid following a symlink cycle back into
+// itself) when followSymlinks is set. depthOffset is the depth already
+// consumed by any ancestor symlink hops, so maxDepth bounds the full chain
+// rather than resetting at each hop.
+func countFilesTree(path string, depthOffset, maxDepth int, followSymlinks bool, visited map[string]bool, count *int64) {
+	realPath, err := filepath.EvalSymlinks(path)
 	if err != nil {
-		return nil, err
+		realPath = path
 	}
+	if visited[realPath] {
+		return
+	}
+	visited[realPath] = true
 
-	var plots []massutil.BindingPlot
-	var totalSearched int
+	baseDepth := strings.Count(filepath.Clean(path), string(os.PathSeparator))
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		depth := depthOffset + strings.Count(filepath.Clean(p), string(os.PathSeparator)) - baseDepth
+		if d.IsDir() {
+			if p != path && maxDepth >= 0 && depth > maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type()&os.ModeSymlink != 0 {
+			if !followSymlinks {
+				return nil
+			}
+			target, statErr := os.Stat(p)
+			if statErr != nil {
+				return nil
+			}
+			if target.IsDir() {
+				if maxDepth < 0 || depth <= maxDepth {
+					countFilesTree(p, depth, maxDepth, followSymlinks, visited, count)
+				}
+				return nil
+			}
+		}
+		*count++
+		return nil
+	})
+	if err != nil {
+		logging.CPrint(logging.WARN, "fail to pre-count files for progress estimate", logging.LogFormat{"err": err, "dir": path})
+	}
+}
+
+// walkPlotDirs recursively walks dirs up to maxDepth levels below each entry
+// (maxDepth < 0 means unlimited), sending the path of every file matching
+// match onto files. It stops early once ctx is done.
+func walkPlotDirs(ctx context.Context, dirs []string, followSymlinks bool, maxDepth int, match func(name string) bool, files chan<- string, reporter *progress.Reporter) error {
+	for _, dir := range dirs {
+		if err := walkDirTree(ctx, dir, dir, 0, maxDepth, followSymlinks, make(map[string]bool), match, files, reporter); err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkDirTree walks path (reporting progress events under reportDir, the
+// --dirs entry this tree was reached from), recursing into directory
+// symlinks when followSymlinks is set instead of just skipping them. visited
+// tracks resolved real paths across the whole call tree rooted at reportDir
+// so a symlink cycle can't be followed back into itself, and depthOffset is
+// the depth already consumed by any ancestor symlink hops, so maxDepth
+// bounds the full chain rather than resetting at each hop.
+func walkDirTree(ctx context.Context, reportDir, path string, depthOffset, maxDepth int, followSymlinks bool, visited map[string]bool, match func(name string) bool, files chan<- string, reporter *progress.Reporter) error {
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		realPath = path
+	}
+	if visited[realPath] {
+		return nil
+	}
+	visited[realPath] = true
+
+	baseDepth := strings.Count(filepath.Clean(path), string(os.PathSeparator))
 
-	for _, dbDir := range dirs {
-		dirFileInfos, err := ioutil.ReadDir(dbDir)
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			return nil, err
+			logging.CPrint(logging.WARN, "fail to access path while scanning", logging.LogFormat{"err": err, "path": p})
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
 
-		logging.CPrint(logging.INFO, "searching for native MassDB files", logging.LogFormat{"dir": dbDir})
+		depth := depthOffset + strings.Count(filepath.Clean(p), string(os.PathSeparator)) - baseDepth
 
-		dirSearched := 0
-		for _, fi := range dirFileInfos {
-			select {
-			case <-interruptCh:
-				logging.CPrint(logging.WARN, "cancel searching plot files")
-				return nil, nil
-			default:
+		if d.IsDir() {
+			if p != path && maxDepth >= 0 && depth > maxDepth {
+				return filepath.SkipDir
 			}
+			return nil
+		}
 
-			fileName := fi.Name()
-			// try match suffix and `ordinal_pubKey_bitLength.suffix`
-			if !strings.HasSuffix(strings.ToUpper(fileName), suffixB) || !regExpB.MatchString(strings.ToUpper(fileName)) {
-				continue
+		if d.Type()&os.ModeSymlink != 0 {
+			if !followSymlinks {
+				return nil
 			}
+			target, statErr := os.Stat(p)
+			if statErr != nil {
+				return nil
+			}
+			if target.IsDir() {
+				if maxDepth >= 0 && depth > maxDepth {
+					return nil
+				}
+				return walkDirTree(ctx, reportDir, p, depth, maxDepth, followSymlinks, visited, match, files, reporter)
+			}
+		}
 
-			info, err := massutil.NewMassDBInfoV1FromFile(filepath.Join(dbDir, fileName))
-			if err != nil {
-				logging.CPrint(logging.WARN, "fail to read native massdb info", logging.LogFormat{"err": err})
-				continue
+		if reporter != nil {
+			reporter.Report(progress.Event{Dir: reportDir, Kind: progress.EventVisited, File: p})
+		}
+
+		if !match(d.Name()) {
+			return nil
+		}
+		if reporter != nil {
+			reporter.Report(progress.Event{Dir: reportDir, Kind: progress.EventMatched})
+			if info, infoErr := d.Info(); infoErr == nil {
+				reporter.Report(progress.Event{Dir: reportDir, Kind: progress.EventBytesRead, Bytes: info.Size()})
 			}
+		}
 
-			if !info.Plotted && !all {
-				continue
-			} else {
-				target, err := massutil.GetMassDBBindingTarget(info.PublicKey, info.BitLength)
+		select {
+		case files <- p:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// runPlotWorkers fans the paths read from files out onto a bounded pool of
+// workers running process, joining their results once every worker and the
+// producer feeding files have finished. It also returns a plot-identity ->
+// source-path map so callers can later verify a plot still exists on disk
+// (see --prune).
+func runPlotWorkers(ctx context.Context, workers int, files <-chan string, process func(path string) (*massutil.BindingPlot, error)) ([]massutil.BindingPlot, map[string]string, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		plots    []massutil.BindingPlot
+		paths    = make(map[string]string)
+		firstErr error
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range files {
+				if ctx.Err() != nil {
+					continue
+				}
+				plot, err := process(path)
 				if err != nil {
-					return nil, err
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
 				}
-				plots = append(plots, massutil.BindingPlot{
-					Target: target,
-					Type:   uint8(poc.ProofTypeDefault),
-					Size:   uint8(info.BitLength),
-				})
-				dirSearched += 1
+				if plot == nil {
+					continue
+				}
+				mu.Lock()
+				plots = append(plots, *plot)
+				paths[plotIdentity(*plot)] = path
+				mu.Unlock()
 			}
+		}()
+	}
+
+	wg.Wait()
+	if firstErr == nil && ctx.Err() != nil {
+		// The scan was cancelled (CTRL+C/SIGTERM) before every queued file was
+		// processed: tell the caller this result is partial rather than
+		// returning it as if the scan had completed normally.
+		firstErr = ctx.Err()
+	}
+	return plots, paths, firstErr
+}
+
+func getOfflineBindingListV1(ctx context.Context, dirs []string, all bool, reporter *progress.Reporter) ([]massutil.BindingPlot, map[string]string, error) {
+	regStrB, suffixB := `^\d+_[A-F0-9]{66}_\d{2}\.MASSDB$`, ".MASSDB"
+	regExpB, err := regexp.Compile(regStrB)
+	if err != nil {
+		return nil, nil, err
+	}
+	match := func(name string) bool {
+		upper := strings.ToUpper(name)
+		return strings.HasSuffix(upper, suffixB) && regExpB.MatchString(upper)
+	}
+
+	logging.CPrint(logging.INFO, "searching for native MassDB files", logging.LogFormat{"dir_count": len(dirs)})
+
+	files := make(chan string, getBindingListFlagWorkers*4)
+	go func() {
+		defer close(files)
+		if err := walkPlotDirs(ctx, dirs, getBindingListFlagFollowSymlinks, getBindingListFlagMaxDepth, match, files, reporter); err != nil {
+			logging.CPrint(logging.ERROR, "fail to walk directories for native MassDB files", logging.LogFormat{"err": err})
 		}
+	}()
 
-		logging.CPrint(logging.INFO, "loaded native MassDB files from directory", logging.LogFormat{
-			"dir":      dbDir,
-			"db_count": dirSearched,
-		})
-		totalSearched += dirSearched
+	plots, paths, err := runPlotWorkers(ctx, getBindingListFlagWorkers, files, func(path string) (*massutil.BindingPlot, error) {
+		info, err := massutil.NewMassDBInfoV1FromFile(path)
+		if err != nil {
+			logging.CPrint(logging.WARN, "fail to read native massdb info", logging.LogFormat{"err": err, "file": path})
+			return nil, nil
+		}
+		if !info.Plotted && !all {
+			return nil, nil
+		}
+		target, err := massutil.GetMassDBBindingTarget(info.PublicKey, info.BitLength)
+		if err != nil {
+			return nil, err
+		}
+		return &massutil.BindingPlot{
+			Target: target,
+			Type:   uint8(poc.ProofTypeDefault),
+			Size:   uint8(info.BitLength),
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
+	deduped := (&massutil.BindingList{Plots: plots}).RemoveDuplicate().Plots
 	logging.CPrint(logging.INFO, "loaded native MassDB files from all directories", logging.LogFormat{
 		"dir_count":      len(dirs),
-		"total_db_count": totalSearched,
+		"total_db_count": len(deduped),
 	})
+	return deduped, paths, nil
+}
+
+// namedKeystore pairs a loaded chiawallet.Keystore with the file it came
+// from, so ownership claims can be attributed to a specific wallet.
+type namedKeystore struct {
+	name     string
+	keystore *chiawallet.Keystore
+}
 
-	return plots, nil
+// compositeKeystore consults multiple keystores and considers a plot owned
+// if any one of them holds both the pool and farmer private keys for it. A
+// plot whose pool key is in one keystore and farmer key in another is not
+// considered owned.
+type compositeKeystore struct {
+	keystores []namedKeystore
 }
 
-func getOfflineBindingListV2(interruptCh chan os.Signal, dirs []string, all bool, keystoreFile string) ([]massutil.BindingPlot, error) {
+func loadCompositeKeystore(files []string) (*compositeKeystore, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	ck := &compositeKeystore{}
+	for _, file := range files {
+		keystore, err := chiawallet.NewKeystoreFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		ck.keystores = append(ck.keystores, namedKeystore{name: file, keystore: keystore})
+	}
+	return ck, nil
+}
+
+// owner returns the file name of the first loaded keystore that owns both
+// the pool and farmer keys for info, or "" if none does.
+func (ck *compositeKeystore) owner(info *massutil.MassDBInfoV2) string {
+	for _, nk := range ck.keystores {
+		if _, err := nk.keystore.GetPoolPrivateKey(info.PoolPublicKey); err != nil {
+			continue
+		}
+		if _, err := nk.keystore.GetFarmerPrivateKey(info.FarmerPublicKey); err != nil {
+			continue
+		}
+		return nk.name
+	}
+	return ""
+}
+
+func getOfflineBindingListV2(ctx context.Context, dirs []string, all bool, keystoreFiles []string, reporter *progress.Reporter) ([]massutil.BindingPlot, map[string]string, error) {
 	regStrB, suffixB := `^PLOT-K\d{2}-\d{4}(-\d{2}){4}-[A-F0-9]{64}\.PLOT$`, ".PLOT"
 	regExpB, err := regexp.Compile(regStrB)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	match := func(name string) bool {
+		upper := strings.ToUpper(name)
+		return strings.HasSuffix(upper, suffixB) && regExpB.MatchString(upper)
 	}
 
-	var keystore *chiawallet.Keystore
-	if keystoreFile != "" {
-		if keystore, err = chiawallet.NewKeystoreFromFile(keystoreFile); err != nil {
+	keystore, err := loadCompositeKeystore(keystoreFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logging.CPrint(logging.INFO, "searching for chia plot files", logging.LogFormat{"dir_count": len(dirs), "keystore_count": len(keystoreFiles)})
+
+	scanCtx, scanCancel := context.WithCancel(ctx)
+	defer scanCancel()
+
+	files := make(chan string, getBindingListFlagWorkers*4)
+	go func() {
+		defer close(files)
+		if err := walkPlotDirs(scanCtx, dirs, getBindingListFlagFollowSymlinks, getBindingListFlagMaxDepth, match, files, reporter); err != nil {
+			logging.CPrint(logging.ERROR, "fail to walk directories for chia plot files", logging.LogFormat{"err": err})
+		}
+	}()
+
+	plots, paths, err := runPlotWorkers(scanCtx, getBindingListFlagWorkers, files, func(path string) (*massutil.BindingPlot, error) {
+		info, err := massutil.NewMassDBInfoV2FromFile(path)
+		if err != nil {
+			logging.CPrint(logging.WARN, "fail to read chia plot info", logging.LogFormat{"err": err, "file": path})
+			return nil, nil
+		}
+
+		if keystore != nil {
+			owner := keystore.owner(info)
+			if owner == "" {
+				if getBindingListFlagRequireOwnership {
+					scanCancel()
+					return nil, fmt.Errorf("plot %s is not owned by any configured keystore", path)
+				}
+				return nil, nil
+			}
+			logging.CPrint(logging.INFO, "plot claimed by keystore", logging.LogFormat{"file": path, "keystore": owner})
+		}
+
+		target, err := massutil.GetChiaPlotBindingTarget(info.PlotID, info.K)
+		if err != nil {
 			return nil, err
 		}
+		return &massutil.BindingPlot{
+			Target: target,
+			Type:   uint8(poc.ProofTypeChia),
+			Size:   uint8(info.K),
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var ownablePlot = func(info *massutil.MassDBInfoV2) bool {
-		if keystore == nil {
-			return true
+	deduped := (&massutil.BindingList{Plots: plots}).RemoveDuplicate().Plots
+	logging.CPrint(logging.INFO, "loaded chia plot files from all directories", logging.LogFormat{
+		"dir_count":      len(dirs),
+		"total_db_count": len(deduped),
+	})
+	return deduped, paths, nil
+}
+
+// watchCommand keeps the process alive, periodically re-scanning --dirs and
+// reacting to filesystem events, so a MASS miner or sidecar can pull the
+// latest bindings without a filesystem hop.
+var watchCommand = &cli.Command{
+	Name:      "watch",
+	Usage:     "keep re-scanning --dirs and exporting the binding list until stopped",
+	UsageText: "./massBindingTarget watch <export_filename> [flags]",
+	Flags: append(append([]cli.Flag{}, scanFlags...),
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "period between re-scans, in addition to reacting to filesystem events on --dirs",
+			Value: 10 * time.Minute,
+		},
+		&cli.StringFlag{
+			Name:  "listen",
+			Usage: "serve the current binding list over HTTP on this address (e.g. :8080), disabled if empty",
+			Value: "",
+		},
+	),
+	Action: runWatch,
+}
+
+// watchState holds the most recently exported binding list for the HTTP
+// endpoints, guarded by mu since it is read by the HTTP handlers and
+// written by the scan loop concurrently.
+type watchState struct {
+	mu         sync.RWMutex
+	data       []byte
+	hash       [sha256.Size]byte
+	lastScan   time.Time
+	totalCount uint64
+	dirErrors  map[string]string
+}
+
+func (s *watchState) snapshot() ([]byte, time.Time, uint64, map[string]string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data, s.lastScan, s.totalCount, s.dirErrors
+}
+
+// update records the freshly scanned data and reports whether it differs
+// from what was previously exported, so the caller can skip rewriting the
+// output file when nothing changed.
+func (s *watchState) update(data []byte, totalCount uint64, dirErrors map[string]string) (changed bool) {
+	hash := sha256.Sum256(data)
+	dirErrorsCopy := make(map[string]string, len(dirErrors))
+	for k, v := range dirErrors {
+		dirErrorsCopy[k] = v
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed = hash != s.hash
+	s.data = data
+	s.hash = hash
+	s.lastScan = time.Now()
+	s.totalCount = totalCount
+	s.dirErrors = dirErrorsCopy
+	return changed
+}
+
+// addWatchTree registers fsWatcher on dir and every subdirectory beneath it.
+// fsnotify only watches the literal path passed to Add, not its descendants,
+// but plots are commonly sharded into per-drive/per-K subfolders, so a
+// top-level-only watch would miss events for most of the tree.
+func addWatchTree(fsWatcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			logging.CPrint(logging.WARN, "fail to access path while registering filesystem watch", logging.LogFormat{"err": err, "path": path})
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
 		}
-		if _, err := keystore.GetPoolPrivateKey(info.PoolPublicKey); err != nil {
-			return false
+		if !d.IsDir() {
+			return nil
 		}
-		if _, err := keystore.GetFarmerPrivateKey(info.FarmerPublicKey); err != nil {
-			return false
+		if err := fsWatcher.Add(path); err != nil {
+			logging.CPrint(logging.WARN, "fail to watch directory for filesystem events", logging.LogFormat{"err": err, "dir": path})
 		}
-		return true
+		return nil
+	})
+}
+
+func runWatch(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cli.ShowCommandHelp(c, "watch")
+	}
+	abs, err := filepath.Abs(c.Args().First())
+	if err != nil {
+		logging.CPrint(logging.ERROR, "wrong filename format", logging.LogFormat{"err": err, "filename": c.Args().First()})
+		return err
+	}
+	getBindingListArgFilename = abs
+	applyScanFlags(c)
+	if err := validateScanFlags(); err != nil {
+		logging.CPrint(logging.ERROR, "invalid flag value", logging.LogFormat{"err": err})
+		return err
 	}
 
-	var plots []massutil.BindingPlot
-	var totalSearched int
+	interval := c.Duration("interval")
+	listenAddr := c.String("listen")
+
+	interruptCh := make(chan os.Signal, 2)
+	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-interruptCh:
+			logging.CPrint(logging.WARN, "stopping watcher, cancelling any running scan")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
 
-	for _, dbDir := range dirs {
-		dirFileInfos, err := ioutil.ReadDir(dbDir)
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsWatcher.Close()
+	dirErrors := make(map[string]string)
+	for _, dir := range getBindingListFlagDirectories {
+		absDir, err := filepath.Abs(dir)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		if err := addWatchTree(fsWatcher, absDir); err != nil {
+			logging.CPrint(logging.WARN, "fail to watch directory tree for filesystem events", logging.LogFormat{"err": err, "dir": absDir})
+			dirErrors[absDir] = err.Error()
+		}
+	}
 
-		logging.CPrint(logging.INFO, "searching for chia plot files", logging.LogFormat{"dir": dbDir})
+	state := &watchState{}
 
-		dirSearched := 0
-		for _, fi := range dirFileInfos {
-			select {
-			case <-interruptCh:
-				logging.CPrint(logging.WARN, "cancel searching plot files")
-				return nil, nil
-			default:
+	if listenAddr != "" {
+		server := newWatchServer(listenAddr, state)
+		go func() {
+			logging.CPrint(logging.INFO, "serving binding list over http", logging.LogFormat{"listen": listenAddr})
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logging.CPrint(logging.ERROR, "http server stopped unexpectedly", logging.LogFormat{"err": err})
 			}
+		}()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			server.Shutdown(shutdownCtx)
+		}()
+	}
 
-			fileName := fi.Name()
-			if !strings.HasSuffix(strings.ToUpper(fileName), suffixB) || !regExpB.MatchString(strings.ToUpper(fileName)) {
-				continue
-			}
+	rescan := make(chan struct{}, 1)
+	triggerRescan := func() {
+		select {
+		case rescan <- struct{}{}:
+		default:
+		}
+	}
+	triggerRescan()
 
-			info, err := massutil.NewMassDBInfoV2FromFile(filepath.Join(dbDir, fileName))
-			if err != nil {
-				logging.CPrint(logging.WARN, "fail to read chia plot info", logging.LogFormat{"err": err})
-				continue
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-fsWatcher.Events:
+				if !ok {
+					return
+				}
+				if ev.Op&fsnotify.Create != 0 {
+					if fi, statErr := os.Stat(ev.Name); statErr == nil && fi.IsDir() {
+						if err := addWatchTree(fsWatcher, ev.Name); err != nil {
+							logging.CPrint(logging.WARN, "fail to watch new directory for filesystem events", logging.LogFormat{"err": err, "dir": ev.Name})
+						}
+					}
+				}
+				logging.CPrint(logging.INFO, "filesystem event observed, scheduling re-scan", logging.LogFormat{"event": ev.String()})
+				triggerRescan()
+			case watchErr, ok := <-fsWatcher.Errors:
+				if !ok {
+					return
+				}
+				logging.CPrint(logging.WARN, "fsnotify error", logging.LogFormat{"err": watchErr})
 			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-			if !ownablePlot(info) {
-				continue
+	for {
+		select {
+		case <-ctx.Done():
+			logging.CPrint(logging.INFO, "watcher stopped")
+			return nil
+		case <-ticker.C:
+			triggerRescan()
+		case <-rescan:
+			if err := scanAndExport(ctx, state, dirErrors); err != nil {
+				logging.CPrint(logging.ERROR, "fail to re-scan", logging.LogFormat{"err": err})
+				dirErrors["scan"] = err.Error()
 			} else {
-				target, err := massutil.GetChiaPlotBindingTarget(info.PlotID, info.K)
-				if err != nil {
-					return nil, err
-				}
-				plots = append(plots, massutil.BindingPlot{
-					Target: target,
-					Type:   uint8(poc.ProofTypeChia),
-					Size:   uint8(info.K),
-				})
-				dirSearched += 1
+				delete(dirErrors, "scan")
 			}
 		}
+	}
+}
 
-		logging.CPrint(logging.INFO, "loaded chia plot files from directory", logging.LogFormat{
-			"dir":      dbDir,
-			"db_count": dirSearched,
-		})
-		totalSearched += dirSearched
+// scanAndExport runs one full scan and, only if the resulting binding list
+// differs from the last one exported, atomically rewrites the output file.
+// dirErrors is surfaced as-is on /healthz alongside the scan outcome. ctx is
+// the watch command's long-lived, interrupt-aware context, reused across
+// every re-scan instead of each call registering its own signal handling.
+func scanAndExport(ctx context.Context, state *watchState, dirErrors map[string]string) error {
+	list, paths, err := getOfflineBindingList(ctx)
+	if err != nil {
+		return err
 	}
+	list = list.RemoveDuplicate()
 
-	logging.CPrint(logging.INFO, "loaded chia plot files from all directories", logging.LogFormat{
-		"dir_count":      len(dirs),
-		"total_db_count": totalSearched,
-	})
+	data, err := json.MarshalIndent(&bindingListFile{BindingList: *list, Paths: paths}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if !state.update(data, list.TotalCount, dirErrors) {
+		logging.CPrint(logging.INFO, "binding list unchanged since last scan", logging.LogFormat{"total_count": list.TotalCount})
+		return nil
+	}
+
+	if err := atomicWriteFile(getBindingListArgFilename, data); err != nil {
+		// Forget the hash so the next scan retries the write even if the
+		// list has not changed since.
+		state.mu.Lock()
+		state.hash = [sha256.Size]byte{}
+		state.mu.Unlock()
+		return err
+	}
+	logging.CPrint(logging.INFO, "exported updated binding list", logging.LogFormat{"total_count": list.TotalCount, "filename": getBindingListArgFilename})
+	return nil
+}
+
+// atomicWriteFile writes data to a sibling temp file and renames it into
+// place, so readers of filename (e.g. a MASS miner) never observe a partial
+// write.
+func atomicWriteFile(filename string, data []byte) error {
+	tmp := filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
 
-	return plots, err
+func newWatchServer(addr string, state *watchState) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/binding.json", func(w http.ResponseWriter, r *http.Request) {
+		data, _, _, _ := state.snapshot()
+		if data == nil {
+			http.Error(w, "no scan completed yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, lastScan, totalCount, dirErrors := state.snapshot()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"last_scan":   lastScan,
+			"total_count": totalCount,
+			"dir_errors":  dirErrors,
+		})
+	})
+	return &http.Server{Addr: addr, Handler: mux}
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/massnetorg/mass-core/massutil"
+	"github.com/massnetorg/mass-core/poc"
+	"github.com/massnetorg/mass-core/poc/chiapos"
+	"github.com/massnetorg/mass-core/poc/chiawallet"
+)
+
+func plot(typ uint8, size uint8) massutil.BindingPlot {
+	return massutil.BindingPlot{Type: typ, Size: size}
+}
+
+func TestPlotIdentity(t *testing.T) {
+	a := plot(uint8(poc.ProofTypeDefault), 32)
+	b := plot(uint8(poc.ProofTypeDefault), 32)
+	c := plot(uint8(poc.ProofTypeChia), 32)
+
+	if plotIdentity(a) != plotIdentity(b) {
+		t.Fatalf("identical plots should have identical identities: %q vs %q", plotIdentity(a), plotIdentity(b))
+	}
+	if plotIdentity(a) == plotIdentity(c) {
+		t.Fatalf("plots differing only by Type should have different identities")
+	}
+}
+
+func TestRecomputeCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		plots    []massutil.BindingPlot
+		wantDef  uint64
+		wantChia uint64
+	}{
+		{name: "empty", plots: nil, wantDef: 0, wantChia: 0},
+		{
+			name: "mixed types",
+			plots: []massutil.BindingPlot{
+				plot(uint8(poc.ProofTypeDefault), 30),
+				plot(uint8(poc.ProofTypeDefault), 32),
+				plot(uint8(poc.ProofTypeChia), 32),
+			},
+			wantDef:  2,
+			wantChia: 1,
+		},
+		{
+			name: "unrecognized type is not counted either way",
+			plots: []massutil.BindingPlot{
+				plot(uint8(poc.ProofTypeDefault), 30),
+				plot(255, 32),
+			},
+			wantDef:  1,
+			wantChia: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := &massutil.BindingList{Plots: tt.plots}
+			recomputeCounts(list)
+			if list.DefaultCount != tt.wantDef {
+				t.Errorf("DefaultCount = %d, want %d", list.DefaultCount, tt.wantDef)
+			}
+			if list.ChiaCount != tt.wantChia {
+				t.Errorf("ChiaCount = %d, want %d", list.ChiaCount, tt.wantChia)
+			}
+			if list.TotalCount != tt.wantDef+tt.wantChia {
+				t.Errorf("TotalCount = %d, want %d", list.TotalCount, tt.wantDef+tt.wantChia)
+			}
+		})
+	}
+}
+
+func TestPruneMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	present := filepath.Join(dir, "present.MASSDB")
+	if err := os.WriteFile(present, []byte("x"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.MASSDB")
+
+	presentPlot := plot(uint8(poc.ProofTypeDefault), 30)
+	missingPlot := plot(uint8(poc.ProofTypeDefault), 31)
+	unverifiablePlot := plot(uint8(poc.ProofTypeChia), 32)
+
+	list := &massutil.BindingList{Plots: []massutil.BindingPlot{presentPlot, missingPlot, unverifiablePlot}}
+	paths := map[string]string{
+		plotIdentity(presentPlot): present,
+		plotIdentity(missingPlot): missing,
+		// unverifiablePlot intentionally has no entry in paths.
+	}
+
+	kept, pruned, unverifiable := pruneMissing(list, paths)
+
+	if kept != 2 {
+		t.Errorf("kept = %d, want 2", kept)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned = %d, want 1", pruned)
+	}
+	if unverifiable != 1 {
+		t.Errorf("unverifiable = %d, want 1", unverifiable)
+	}
+	if len(list.Plots) != 2 {
+		t.Fatalf("list.Plots has %d entries, want 2", len(list.Plots))
+	}
+	for _, p := range list.Plots {
+		if plotIdentity(p) == plotIdentity(missingPlot) {
+			t.Errorf("missing plot should have been pruned from list.Plots")
+		}
+	}
+}
+
+func TestPruneMissingEmptyPaths(t *testing.T) {
+	p := plot(uint8(poc.ProofTypeDefault), 30)
+	list := &massutil.BindingList{Plots: []massutil.BindingPlot{p}}
+
+	kept, pruned, unverifiable := pruneMissing(list, map[string]string{})
+
+	if kept != 1 || pruned != 0 || unverifiable != 1 {
+		t.Errorf("kept=%d pruned=%d unverifiable=%d, want 1/0/1", kept, pruned, unverifiable)
+	}
+	if len(list.Plots) != 1 {
+		t.Errorf("list.Plots has %d entries, want 1", len(list.Plots))
+	}
+}
+
+func TestDiffBindingLists(t *testing.T) {
+	common := plot(uint8(poc.ProofTypeDefault), 30)
+	onlyPrev := plot(uint8(poc.ProofTypeDefault), 31)
+	onlyNext := plot(uint8(poc.ProofTypeChia), 32)
+
+	prev := &massutil.BindingList{Plots: []massutil.BindingPlot{common, onlyPrev}}
+	next := &massutil.BindingList{Plots: []massutil.BindingPlot{common, onlyNext}}
+
+	added, removed := diffBindingLists(prev, next)
+
+	if len(added) != 1 || plotIdentity(added[0]) != plotIdentity(onlyNext) {
+		t.Errorf("added = %v, want [%v]", added, onlyNext)
+	}
+	if len(removed) != 1 || plotIdentity(removed[0]) != plotIdentity(onlyPrev) {
+		t.Errorf("removed = %v, want [%v]", removed, onlyPrev)
+	}
+}
+
+func TestDiffBindingListsEmpty(t *testing.T) {
+	empty := &massutil.BindingList{}
+	added, removed := diffBindingLists(empty, empty)
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("diffing two empty lists should report no changes, got added=%v removed=%v", added, removed)
+	}
+}
+
+func TestParsePlotTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty is an error", flag: "", wantErr: true},
+		{name: "m1 only", flag: "m1", want: []string{"m1"}},
+		{name: "m2 only", flag: "m2", want: []string{"m2"}},
+		{name: "all expands to both, m1 first", flag: "all", want: []string{"m1", "m2"}},
+		{name: "comma list preserves order", flag: "m2,m1", want: []string{"m2", "m1"}},
+		{name: "duplicates are deduplicated", flag: "m1,m1,m2", want: []string{"m1", "m2"}},
+		{name: "all after an explicit type only adds the missing one", flag: "m2,all", want: []string{"m2", "m1"}},
+		{name: "whitespace and case are tolerated", flag: " M1 , m2 ", want: []string{"m1", "m2"}},
+		{name: "unknown value is an error", flag: "m3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePlotTypes(tt.flag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePlotTypes(%q) = %v, want an error", tt.flag, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePlotTypes(%q) returned unexpected error: %v", tt.flag, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePlotTypes(%q) = %v, want %v", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCompositeKeystoreEmpty(t *testing.T) {
+	ck, err := loadCompositeKeystore(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ck != nil {
+		t.Errorf("loadCompositeKeystore(nil) = %v, want nil", ck)
+	}
+}
+
+// TestCompositeKeystoreOwnerNoMatch covers the "no keystore owns this plot"
+// case: zero-value keystores hold no keys, so every lookup misses and owner
+// must report "" rather than picking one arbitrarily.
+func TestCompositeKeystoreOwnerNoMatch(t *testing.T) {
+	ck := &compositeKeystore{keystores: []namedKeystore{
+		{name: "wallet-a.keystore", keystore: &chiawallet.Keystore{}},
+		{name: "wallet-b.keystore", keystore: &chiawallet.Keystore{}},
+	}}
+
+	info := &massutil.MassDBInfoV2{PoolPublicKey: &chiapos.G1Element{}, FarmerPublicKey: &chiapos.G1Element{}}
+	if owner := ck.owner(info); owner != "" {
+		t.Errorf("owner() = %q, want \"\" when no keystore holds the plot's keys", owner)
+	}
+}